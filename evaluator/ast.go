@@ -0,0 +1,138 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import "fmt"
+
+// node is implemented by every element of the AST produced by the parser.
+// Operator and function handlers are resolved once, at parse time, so
+// eval never needs to consult the evaluator's operator tables again.
+type node interface {
+	eval(vars map[string]int) (int, error)
+}
+
+// numberNode is a literal integer.
+type numberNode struct {
+	value int
+}
+
+func (n *numberNode) eval(map[string]int) (int, error) {
+	return n.value, nil
+}
+
+// identNode looks up a variable by name at evaluation time.
+type identNode struct {
+	name string
+}
+
+func (n *identNode) eval(vars map[string]int) (int, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownIdent, n.name)
+	}
+	return value, nil
+}
+
+// binaryNode applies a registered BinaryHandler to its two operands.
+type binaryNode struct {
+	symbol  string
+	handler BinaryHandler
+	left    node
+	right   node
+}
+
+func (n *binaryNode) eval(vars map[string]int) (int, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := n.handler(left, right)
+	if err != nil {
+		return 0, fmt.Errorf("operator %q: %w", n.symbol, err)
+	}
+	return result, nil
+}
+
+// unaryNode applies a registered UnaryHandler to its single operand.
+type unaryNode struct {
+	symbol  string
+	handler UnaryHandler
+	operand node
+}
+
+func (n *unaryNode) eval(vars map[string]int) (int, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := n.handler(operand)
+	if err != nil {
+		return 0, fmt.Errorf("operator %q: %w", n.symbol, err)
+	}
+	return result, nil
+}
+
+// ternaryNode implements "cond ? then : else". Only the selected branch
+// is evaluated, so a function call with side effects in the branch that
+// is not taken never runs.
+type ternaryNode struct {
+	cond node
+	then node
+	els  node
+}
+
+func (n *ternaryNode) eval(vars map[string]int) (int, error) {
+	cond, err := n.cond.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	if cond != 0 {
+		return n.then.eval(vars)
+	}
+	return n.els.eval(vars)
+}
+
+// callNode invokes a registered FunctionHandler with its evaluated
+// arguments.
+type callNode struct {
+	name    string
+	handler FunctionHandler
+	args    []node
+}
+
+func (n *callNode) eval(vars map[string]int) (int, error) {
+	args := make([]int, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+
+	result, err := n.handler(args...)
+	if err != nil {
+		return 0, fmt.Errorf("function %q: %w", n.name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLex is wrapped by the error Evaluate, Compile, and EvaluateValue
+// return when expression cannot be tokenized, e.g. an unexpected
+// character or an unterminated string literal.
+var ErrLex = errors.New("lexical error")
+
+// ErrParse is wrapped by the error Evaluate and Compile return when
+// expression tokenizes but cannot be parsed into an AST, e.g. a missing
+// operand or an unbalanced parenthesis.
+var ErrParse = errors.New("parse error")
+
+// ErrDivByZero is returned by the built-in "/" and "%" operators, in both
+// Evaluate and EvaluateValue, when the right-hand operand is zero, so
+// callers can distinguish it from other evaluation failures with
+// errors.Is.
+var ErrDivByZero = errors.New("division by zero")
+
+// ErrUnknownIdent is returned when an expression refers to a variable
+// that is not present in the values map passed to Eval, so callers can
+// distinguish it from other evaluation failures with errors.Is.
+var ErrUnknownIdent = errors.New("unknown identifier")
+
+// ErrDivisionByZero is an alias of ErrDivByZero kept for EvaluateValue
+// callers that already matched on this name; it is the exact same
+// sentinel, so errors.Is works against either name.
+var ErrDivisionByZero = ErrDivByZero
+
+// ErrTypeMismatch is returned by EvaluateValue when an operator is
+// applied to operands of incompatible Kind (e.g. a string added to a
+// bool), so callers can distinguish it from other evaluation failures
+// with errors.Is.
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// ParseError is returned by tokenize and the parsers for every lexical or
+// syntax failure. It carries enough structure - the byte offset into the
+// original expression, the offending token's literal text, and the set
+// of things that would have been accepted there - for a caller to point
+// a user at the exact spot a rule expression is malformed, rather than
+// just displaying an opaque message.
+type ParseError struct {
+	// Pos is the byte offset of the offending token within the original
+	// expression.
+	Pos int
+	// Token is the offending token's literal text, or "" for an error
+	// that occurred at the end of the expression.
+	Token string
+	// Expected lists, in human-readable form, what would have been
+	// accepted at Pos instead (e.g. "operand", "')'").
+	Expected []string
+	// Wrapped is either ErrLex or ErrParse, identifying which stage
+	// produced the error.
+	Wrapped error
+}
+
+// Error renders e as e.g. `parse error at position 2: expected operand,
+// got "*"` for the expression "1**".
+func (e *ParseError) Error() string {
+	var got string
+	if e.Token == "" {
+		got = "end of expression"
+	} else {
+		got = fmt.Sprintf("%q", e.Token)
+	}
+	return fmt.Sprintf("%s at position %d: expected %s, got %s", e.Wrapped, e.Pos, strings.Join(e.Expected, " or "), got)
+}
+
+// Unwrap returns ErrLex or ErrParse, so errors.Is(err, evaluator.ErrParse)
+// works on a *ParseError the same way it would on a plain wrapped error.
+func (e *ParseError) Unwrap() error {
+	return e.Wrapped
+}
+
+// lexError builds a *ParseError wrapping ErrLex for a failure at pos with
+// the given offending character rendered as its token text.
+func lexError(pos int, token string, expected ...string) error {
+	return &ParseError{Pos: pos, Token: token, Expected: expected, Wrapped: ErrLex}
+}
+
+// parseError builds a *ParseError wrapping ErrParse for a failure at tok.
+func parseError(tok token, expected ...string) error {
+	text := tok.lit
+	if tok.kind == tokenEOF {
+		text = ""
+	}
+	return &ParseError{Pos: tok.pos, Token: text, Expected: expected, Wrapped: ErrParse}
+}
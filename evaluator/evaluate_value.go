@@ -0,0 +1,64 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// valueOperatorSymbols lists every operator symbol EvaluateValue
+// recognizes, for the lexer's maximal-munch tokenization.
+func valueOperatorSymbols() []string {
+	seen := make(map[string]bool, len(valueOperatorPrecedence)+len(valueUnaryOperatorSymbols))
+	var symbols []string
+	for symbol := range valueOperatorPrecedence {
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	for _, symbol := range valueUnaryOperatorSymbols {
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// EvaluateValue parses expression and evaluates it against vars, where
+// both the free variables and the result may be an int, a float64, a
+// string or a bool. Arithmetic between an int and a float64 promotes the
+// int to float64; "+" also concatenates two strings; relational
+// operators compare strings lexicographically and numbers numerically;
+// "&&" and "||" require bool operands. The prefix operators -, +, ! and
+// ~ are supported exactly as in Evaluate: - and + require a numeric
+// operand, ! requires bool and ~ requires int. The ternary
+// cond ? then : else requires a bool cond and evaluates only the
+// selected branch, so e.g.
+// "cluster_version >= 4.10 ? cve_high_count : cve_high_count*2" parses
+// and evaluates here even though cluster_version is a float. Division
+// and modulo by a zero operand return ErrDivisionByZero, applying an
+// operator to operands of incompatible kinds returns ErrTypeMismatch, a
+// reference to a variable missing from vars wraps ErrUnknownIdent, and a
+// malformed expression wraps ErrLex or ErrParse as a *ParseError;
+// callers can tell all of these apart with errors.Is.
+func EvaluateValue(expression string, vars map[string]Value) (Value, error) {
+	tokens, err := tokenize(expression, valueOperatorSymbols())
+	if err != nil {
+		return Value{}, err
+	}
+
+	ast, err := newValueParser(tokens).parse()
+	if err != nil {
+		return Value{}, err
+	}
+
+	return ast.eval(vars)
+}
@@ -0,0 +1,237 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evaluator implements a small expression language for rules that
+// need to be evaluated against a set of named integer values, such as
+// "x > 2 && y <= 10". It is deliberately table-driven: the set of
+// operators and functions understood by an Evaluator is configured
+// through Option values passed to New, instead of being hardcoded into
+// the tokenizer and parser.
+package evaluator
+
+// Evaluator evaluates expressions using a configurable set of operators
+// and functions. The zero value is not usable; create one with New.
+type Evaluator struct {
+	binaryOps map[string]*binaryOperator
+	unaryOps  map[string]*unaryOperator
+	functions map[string]*function
+}
+
+// Option configures an Evaluator created by New.
+type Option func(*Evaluator)
+
+// WithBinaryOperator registers a binary operator with the given symbol,
+// precedence (higher binds tighter) and associativity. Registering a
+// symbol that is already known replaces its previous definition, which
+// allows overriding a built-in operator.
+func WithBinaryOperator(symbol string, precedence int, associativity Associativity, handler BinaryHandler) Option {
+	return func(e *Evaluator) {
+		e.binaryOps[symbol] = &binaryOperator{
+			symbol:        symbol,
+			precedence:    precedence,
+			associativity: associativity,
+			handler:       handler,
+		}
+	}
+}
+
+// WithUnaryOperator registers a prefix unary operator with the given
+// symbol.
+func WithUnaryOperator(symbol string, handler UnaryHandler) Option {
+	return func(e *Evaluator) {
+		e.unaryOps[symbol] = &unaryOperator{symbol: symbol, handler: handler}
+	}
+}
+
+// WithFunction registers a function of fixed arity that can be called
+// from expressions as name(arg1, ..., argN).
+func WithFunction(name string, arity int, handler FunctionHandler) Option {
+	return func(e *Evaluator) {
+		e.functions[name] = &function{name: name, arity: arity, handler: handler}
+	}
+}
+
+// New creates an Evaluator preloaded with the operators understood by the
+// package-level Evaluate function (arithmetic, relational, boolean,
+// bitwise and the prefix unary operators), then
+// applies opts on top, so callers can add operators and functions or
+// override the defaults.
+func New(opts ...Option) *Evaluator {
+	e := &Evaluator{
+		binaryOps: make(map[string]*binaryOperator),
+		unaryOps:  make(map[string]*unaryOperator),
+		functions: make(map[string]*function),
+	}
+
+	for _, opt := range defaultOptions() {
+		opt(e)
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// defaultOptions returns the Option values that configure the operators
+// available through the package-level Evaluate function.
+func defaultOptions() []Option {
+	return []Option{
+		WithBinaryOperator("||", 1, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left != 0 || right != 0), nil
+		}),
+		WithBinaryOperator("&&", 2, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left != 0 && right != 0), nil
+		}),
+		WithBinaryOperator("==", 3, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left == right), nil
+		}),
+		WithBinaryOperator("!=", 3, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left != right), nil
+		}),
+		WithBinaryOperator("<", 4, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left < right), nil
+		}),
+		WithBinaryOperator(">", 4, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left > right), nil
+		}),
+		WithBinaryOperator("<=", 4, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left <= right), nil
+		}),
+		WithBinaryOperator(">=", 4, LeftAssociative, func(left, right int) (int, error) {
+			return boolToInt(left >= right), nil
+		}),
+		WithBinaryOperator("+", 5, LeftAssociative, func(left, right int) (int, error) {
+			return left + right, nil
+		}),
+		WithBinaryOperator("-", 5, LeftAssociative, func(left, right int) (int, error) {
+			return left - right, nil
+		}),
+		// | and ^ sit at the additive precedence level, matching Go's own
+		// operator precedence (spec: "Operator precedence").
+		WithBinaryOperator("|", 5, LeftAssociative, func(left, right int) (int, error) {
+			return left | right, nil
+		}),
+		WithBinaryOperator("^", 5, LeftAssociative, func(left, right int) (int, error) {
+			return left ^ right, nil
+		}),
+		WithBinaryOperator("*", 6, LeftAssociative, func(left, right int) (int, error) {
+			return left * right, nil
+		}),
+		WithBinaryOperator("/", 6, LeftAssociative, func(left, right int) (int, error) {
+			if right == 0 {
+				return 0, ErrDivByZero
+			}
+			return left / right, nil
+		}),
+		WithBinaryOperator("%", 6, LeftAssociative, func(left, right int) (int, error) {
+			if right == 0 {
+				return 0, ErrDivByZero
+			}
+			return left % right, nil
+		}),
+		// &, << and >> sit at the multiplicative precedence level, matching
+		// Go's own operator precedence.
+		WithBinaryOperator("&", 6, LeftAssociative, func(left, right int) (int, error) {
+			return left & right, nil
+		}),
+		WithBinaryOperator("<<", 6, LeftAssociative, func(left, right int) (int, error) {
+			return left << uint(right), nil
+		}),
+		WithBinaryOperator(">>", 6, LeftAssociative, func(left, right int) (int, error) {
+			return left >> uint(right), nil
+		}),
+		WithUnaryOperator("-", func(operand int) (int, error) {
+			return -operand, nil
+		}),
+		WithUnaryOperator("+", func(operand int) (int, error) {
+			return operand, nil
+		}),
+		WithUnaryOperator("!", func(operand int) (int, error) {
+			return boolToInt(operand == 0), nil
+		}),
+		WithUnaryOperator("~", func(operand int) (int, error) {
+			return ^operand, nil
+		}),
+	}
+}
+
+// operatorSymbols returns every operator symbol the evaluator currently
+// recognizes, for the lexer's maximal-munch tokenization.
+func (e *Evaluator) operatorSymbols() []string {
+	symbols := make([]string, 0, len(e.binaryOps)+len(e.unaryOps))
+	for symbol := range e.binaryOps {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range e.unaryOps {
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// Evaluate parses expression and evaluates it against values, using the
+// operators and functions registered on e. It returns 0 together with an
+// error if expression cannot be parsed, refers to a value that is not in
+// values, or fails at runtime (e.g. division by zero); callers can tell
+// these apart with errors.Is against ErrLex, ErrParse, ErrUnknownIdent
+// and ErrDivByZero.
+//
+// Evaluate is a thin wrapper around Compile followed by Program.Eval;
+// callers evaluating the same expression many times should Compile it
+// once instead.
+func (e *Evaluator) Evaluate(expression string, values map[string]int) (int, error) {
+	program, err := e.Compile(expression)
+	if err != nil {
+		return 0, err
+	}
+
+	return program.Eval(values)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// defaultEvaluator backs the package-level Evaluate function with the
+// built-in set of operators.
+var defaultEvaluator = New()
+
+// Evaluate parses expression and evaluates it against values. It supports
+// the arithmetic operators +, -, *, /, % the relational operators <, >,
+// <=, >=, ==, != the boolean operators && and || and the bitwise
+// operators &, |, ^, << and >>, all with their usual precedence, the
+// prefix unary operators -, +, ! and ~, the ternary conditional
+// cond ? then : else, plus parenthesized sub-expressions. It returns 0
+// together with an error if expression cannot be parsed, refers to a
+// value that is not in values, or fails at runtime (e.g. division by
+// zero); callers can tell these apart with errors.Is against ErrLex,
+// ErrParse, ErrUnknownIdent and ErrDivByZero.
+//
+// Every value involved - operands, values, and the result - is an int;
+// there is no decimal-point literal, so e.g.
+// "cluster_version >= 4.10 ? cve_high_count : cve_high_count*2" is a
+// parse error here even though the ternary operator it uses is
+// supported. Use EvaluateValue, which supports the same operators except
+// the binary bitwise ones (&, |, ^, << and >>) against float, string and
+// bool operands too, for expressions like that one.
+//
+// Evaluate is a thin wrapper around an Evaluator preloaded with those
+// operators; use New to register additional operators or functions.
+func Evaluate(expression string, values map[string]int) (int, error) {
+	return defaultEvaluator.Evaluate(expression, values)
+}
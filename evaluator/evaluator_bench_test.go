@@ -0,0 +1,59 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// ruleExpression is a typical insights rule expression: relational and
+// boolean operators combined over a handful of metric-like variables.
+const ruleExpression = "cluster_version >= 410 && (cve_high_count > 0 || memory_usage_percent > 90) && node_count >= 3"
+
+var ruleVars = map[string]int{
+	"cluster_version":      412,
+	"cve_high_count":       2,
+	"memory_usage_percent": 63,
+	"node_count":           5,
+}
+
+// BenchmarkEvaluate re-tokenizes and re-parses ruleExpression on every
+// call, as every caller of Evaluate did before Compile/Program existed.
+func BenchmarkEvaluate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := evaluator.Evaluate(ruleExpression, ruleVars); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkProgramEval compiles ruleExpression once and evaluates the
+// resulting Program repeatedly, as a caller evaluating the same rule
+// against many metric samples should.
+func BenchmarkProgramEval(b *testing.B) {
+	program, err := evaluator.Compile(ruleExpression)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := program.Eval(ruleVars); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
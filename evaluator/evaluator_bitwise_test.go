@@ -0,0 +1,137 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestEvaluatorUnary checks the evaluator.Evaluate function for the
+// prefix unary operators -, +, ! and ~.
+func TestEvaluatorUnary(t *testing.T) {
+	var values = make(map[string]int)
+	testCases := []TestCase{
+		{
+			name:          "unary minus",
+			expression:    "-2",
+			expectedValue: -2,
+		},
+		{
+			name:          "unary plus",
+			expression:    "+2",
+			expectedValue: 2,
+		},
+		{
+			name:          "unary not",
+			expression:    "!0",
+			expectedValue: 1,
+		},
+		{
+			name:          "unary complement",
+			expression:    "~0",
+			expectedValue: -1,
+		},
+		{
+			name:          "minus binds tighter than binary minus",
+			expression:    "5 - -2",
+			expectedValue: 7,
+		},
+		{
+			name:          "double negation",
+			expression:    "!!5",
+			expectedValue: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := evaluator.Evaluate(tc.expression, values)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedValue, result)
+		})
+	}
+}
+
+// TestEvaluatorBitwise checks the evaluator.Evaluate function for the
+// bitwise operators &, |, ^, << and >>, including their precedence
+// relative to each other and to the prefix ~ operator, and the hex and
+// leading-zero number literals they are often used with.
+func TestEvaluatorBitwise(t *testing.T) {
+	var values = make(map[string]int)
+	testCases := []TestCase{
+		{
+			name:          "and",
+			expression:    "6 & 3",
+			expectedValue: 2,
+		},
+		{
+			name:          "or",
+			expression:    "4 | 1",
+			expectedValue: 5,
+		},
+		{
+			name:          "xor",
+			expression:    "5 ^ 3",
+			expectedValue: 6,
+		},
+		{
+			name:          "left shift",
+			expression:    "1 << 10",
+			expectedValue: 1024,
+		},
+		{
+			name:          "right shift",
+			expression:    "1024 >> 10",
+			expectedValue: 1,
+		},
+		{
+			name:          "and binds tighter than or",
+			expression:    "1 | 2 & 3",
+			expectedValue: 3,
+		},
+		{
+			name:          "complement combined with and",
+			expression:    "~0 & 0xff",
+			expectedValue: 255,
+		},
+		{
+			name:          "hexadecimal literal",
+			expression:    "0x10",
+			expectedValue: 16,
+		},
+		{
+			name:          "leading-zero decimal literal stays decimal",
+			expression:    "008",
+			expectedValue: 8,
+		},
+		{
+			name:          "leading-zero decimal literal is not octal",
+			expression:    "010",
+			expectedValue: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := evaluator.Evaluate(tc.expression, values)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedValue, result)
+		})
+	}
+}
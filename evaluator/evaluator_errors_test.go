@@ -0,0 +1,84 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestEvaluatorErrorsDistinguishable checks that the different ways
+// evaluator.Evaluate can fail can be told apart programmatically with
+// errors.Is, and that a parse failure carries a *evaluator.ParseError
+// with the position and token of the offending input.
+func TestEvaluatorErrorsDistinguishable(t *testing.T) {
+	t.Run("lex error", func(t *testing.T) {
+		_, err := evaluator.Evaluate("1 @ 2", map[string]int{})
+
+		assert.ErrorIs(t, err, evaluator.ErrLex)
+
+		var parseErr *evaluator.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 2, parseErr.Pos)
+		assert.Equal(t, "@", parseErr.Token)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		_, err := evaluator.Evaluate("1**", map[string]int{})
+
+		assert.ErrorIs(t, err, evaluator.ErrParse)
+
+		var parseErr *evaluator.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.NotEmpty(t, parseErr.Expected)
+	})
+
+	t.Run("division by zero", func(t *testing.T) {
+		result, err := evaluator.Evaluate("1/0", map[string]int{})
+
+		assert.ErrorIs(t, err, evaluator.ErrDivByZero)
+		assert.Equal(t, 0, result)
+	})
+
+	t.Run("unknown identifier", func(t *testing.T) {
+		result, err := evaluator.Evaluate("missing", map[string]int{})
+
+		assert.ErrorIs(t, err, evaluator.ErrUnknownIdent)
+		assert.Equal(t, 0, result)
+	})
+}
+
+// TestParseErrorMessage checks that ParseError renders a message that
+// names both the offending token and what was expected instead, so a
+// caller showing the raw error string still gets something useful.
+func TestParseErrorMessage(t *testing.T) {
+	_, err := evaluator.Evaluate("(1+2*", map[string]int{})
+
+	var parseErr *evaluator.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Contains(t, parseErr.Error(), "expected")
+}
+
+// TestErrDivisionByZeroIsErrDivByZero checks that Evaluate's
+// ErrDivByZero and EvaluateValue's ErrDivisionByZero are the same
+// sentinel, so errors.Is works against either name regardless of which
+// of the two evaluation functions produced the error.
+func TestErrDivisionByZeroIsErrDivByZero(t *testing.T) {
+	assert.ErrorIs(t, evaluator.ErrDivisionByZero, evaluator.ErrDivByZero)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestEvaluatorDefaultOperators checks that New with no options behaves
+// exactly like the package-level Evaluate function.
+func TestEvaluatorDefaultOperators(t *testing.T) {
+	ev := evaluator.New()
+
+	result, err := ev.Evaluate("1+2*3", map[string]int{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 7, result)
+}
+
+// TestEvaluatorCustomBinaryOperator checks that a caller can register a
+// new binary operator, with its own precedence, that the tokenizer and
+// parser then understand with no further changes.
+func TestEvaluatorCustomBinaryOperator(t *testing.T) {
+	ev := evaluator.New(
+		evaluator.WithBinaryOperator("**", 7, evaluator.RightAssociative, func(left, right int) (int, error) {
+			result := 1
+			for i := 0; i < right; i++ {
+				result *= left
+			}
+			return result, nil
+		}),
+	)
+
+	result, err := ev.Evaluate("2+2**3", map[string]int{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 10, result, "** should bind tighter than +")
+}
+
+// TestEvaluatorCustomUnaryOperator checks that a caller can register a
+// prefix operator unknown to the default evaluator, and that it composes
+// with a built-in prefix operator ("-") on the same operand.
+func TestEvaluatorCustomUnaryOperator(t *testing.T) {
+	ev := evaluator.New(
+		evaluator.WithUnaryOperator("#", func(operand int) (int, error) {
+			if operand < 0 {
+				return -operand, nil
+			}
+			return operand, nil
+		}),
+	)
+
+	result, err := ev.Evaluate("#-5", map[string]int{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 5, result)
+}
+
+// TestEvaluatorCustomFunction checks that a caller can register a
+// user-defined function callable from expressions.
+func TestEvaluatorCustomFunction(t *testing.T) {
+	ev := evaluator.New(
+		evaluator.WithFunction("min", 2, func(args ...int) (int, error) {
+			if args[0] < args[1] {
+				return args[0], nil
+			}
+			return args[1], nil
+		}),
+	)
+
+	result, err := ev.Evaluate("min(3, 1)+1", map[string]int{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 2, result)
+}
+
+// TestEvaluatorUnknownFunction checks that calling an unregistered
+// function is reported as an error.
+func TestEvaluatorUnknownFunction(t *testing.T) {
+	ev := evaluator.New()
+
+	_, err := ev.Evaluate("pow(2, 3)", map[string]int{})
+
+	assert.Error(t, err, "error is expected")
+}
+
+// TestEvaluatorFunctionWrongArity checks that calling a registered
+// function with the wrong number of arguments is reported as an error.
+func TestEvaluatorFunctionWrongArity(t *testing.T) {
+	ev := evaluator.New(
+		evaluator.WithFunction("min", 2, func(args ...int) (int, error) {
+			if args[0] < args[1] {
+				return args[0], nil
+			}
+			return args[1], nil
+		}),
+	)
+
+	_, err := ev.Evaluate("min(1)", map[string]int{})
+
+	assert.Error(t, err, "error is expected")
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestEvaluatorTernary tests the "cond ? then : else" operator, mirroring
+// the rarely-seen-but-must-work cases covered by TestEdgeCases.
+func TestEvaluatorTernary(t *testing.T) {
+	var values = make(map[string]int)
+	testCases := []TestCase{
+		{
+			name:          "true branch",
+			expression:    "1 < 2 ? 10 : 20",
+			expectedValue: 10,
+		},
+		{
+			name:          "false branch",
+			expression:    "1 > 2 ? 10 : 20",
+			expectedValue: 20,
+		},
+		{
+			name:          "lower precedence than ||",
+			expression:    "0 || 1 ? 10 : 20",
+			expectedValue: 10,
+		},
+		{
+			name:          "nested in then branch",
+			expression:    "1 ? 0 ? 3 : 4 : 5",
+			expectedValue: 4,
+		},
+		{
+			name:          "nested in else branch, right associative",
+			expression:    "0 ? 1 : 0 ? 2 : 3",
+			expectedValue: 3,
+		},
+		{
+			name:          "parenthesized condition",
+			expression:    "(1 + 1 == 2) ? 100 : 200",
+			expectedValue: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := evaluator.Evaluate(tc.expression, values)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedValue, result)
+		})
+	}
+}
+
+// TestEvaluatorTernaryShortCircuit checks that only the selected branch is
+// evaluated: the branch not taken calls a function that would error if
+// invoked, and that error must never surface.
+func TestEvaluatorTernaryShortCircuit(t *testing.T) {
+	ev := evaluator.New(
+		evaluator.WithFunction("explode", 0, func(args ...int) (int, error) {
+			t.Fatal("branch not taken must not be evaluated")
+			return 0, nil
+		}),
+	)
+
+	result, err := ev.Evaluate("1 ? 42 : explode()", map[string]int{})
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 42, result)
+
+	result, err = ev.Evaluate("0 ? explode() : 42", map[string]int{})
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 42, result)
+}
+
+// TestEvaluatorTernaryMissingColon checks that a malformed ternary
+// missing its ':' is reported as a parse error.
+func TestEvaluatorTernaryMissingColon(t *testing.T) {
+	_, err := evaluator.Evaluate("1 ? 2", map[string]int{})
+
+	assert.Error(t, err, "error is expected")
+}
@@ -184,16 +184,6 @@ func TestEvaluatorWrongInput(t *testing.T) {
 			expression:    "2+",
 			expectedError: true,
 		},
-		{
-			name:          "no left operand",
-			expression:    "+2",
-			expectedError: true,
-		},
-		{
-			name:          "no left operand (minus)",
-			expression:    "-2",
-			expectedError: true,
-		},
 		{
 			name:          "== typo",
 			expression:    "0=0",
@@ -211,7 +201,7 @@ func TestEvaluatorWrongInput(t *testing.T) {
 			if tc.expectedError {
 				result, err := evaluator.Evaluate(tc.expression, values)
 				assert.Error(t, err, "error is expected")
-				assert.Equal(t, -1, result)
+				assert.Equal(t, 0, result)
 			}
 		})
 	}
@@ -0,0 +1,199 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestEvaluateValueArithmeticInt checks that EvaluateValue evaluates
+// int-only arithmetic exactly like Evaluate, but returns a typed Value.
+func TestEvaluateValueArithmeticInt(t *testing.T) {
+	result, err := evaluator.EvaluateValue("1+2*3", map[string]evaluator.Value{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.IntValue(7), result)
+}
+
+// TestEvaluateValueFloatPromotion checks that mixing an int and a float64
+// operand promotes the result to float64.
+func TestEvaluateValueFloatPromotion(t *testing.T) {
+	result, err := evaluator.EvaluateValue("1+2.5", map[string]evaluator.Value{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.FloatValue(3.5), result)
+}
+
+// TestEvaluateValueStringConcat checks that "+" concatenates two string
+// operands instead of performing arithmetic.
+func TestEvaluateValueStringConcat(t *testing.T) {
+	vars := map[string]evaluator.Value{"cluster": evaluator.StringValue("my-cluster")}
+
+	result, err := evaluator.EvaluateValue(`"name: " + cluster`, vars)
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.StringValue("name: my-cluster"), result)
+}
+
+// TestEvaluateValueStringRelational checks that relational operators
+// compare strings lexicographically.
+func TestEvaluateValueStringRelational(t *testing.T) {
+	result, err := evaluator.EvaluateValue(`"abc" < "abd"`, map[string]evaluator.Value{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.BoolValue(true), result)
+}
+
+// TestEvaluateValueBoolean checks that && and || operate on, and produce,
+// proper bool Values rather than 0/1 integers.
+func TestEvaluateValueBoolean(t *testing.T) {
+	result, err := evaluator.EvaluateValue("1 < 2 && 3.0 >= 2.5", map[string]evaluator.Value{})
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.BoolValue(true), result)
+}
+
+// TestEvaluateValueDivisionByZero checks that dividing by zero returns an
+// error callers can recognize with errors.Is(err, evaluator.ErrDivisionByZero).
+func TestEvaluateValueDivisionByZero(t *testing.T) {
+	_, err := evaluator.EvaluateValue("1/0", map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrDivisionByZero))
+}
+
+// TestEvaluateValueTypeMismatch checks that applying an operator to
+// incompatible operand kinds returns an error callers can recognize with
+// errors.Is(err, evaluator.ErrTypeMismatch).
+func TestEvaluateValueTypeMismatch(t *testing.T) {
+	_, err := evaluator.EvaluateValue(`1 + "a"`, map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrTypeMismatch))
+}
+
+// TestEvaluateValueUnknownIdent checks that a reference to a variable
+// missing from vars returns an error callers can recognize with
+// errors.Is(err, evaluator.ErrUnknownIdent).
+func TestEvaluateValueUnknownIdent(t *testing.T) {
+	_, err := evaluator.EvaluateValue("missing", map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrUnknownIdent))
+}
+
+// TestEvaluateValueUnary checks that the prefix operators -, +, ! and ~
+// work against Values, including a negative literal and negating an
+// already-negative operand.
+func TestEvaluateValueUnary(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		vars       map[string]evaluator.Value
+		expected   evaluator.Value
+	}{
+		{
+			name:       "negative float literal",
+			expression: "-2.5",
+			vars:       map[string]evaluator.Value{},
+			expected:   evaluator.FloatValue(-2.5),
+		},
+		{
+			name:       "binary minus followed by a negative literal",
+			expression: "x - -1",
+			vars:       map[string]evaluator.Value{"x": evaluator.IntValue(5)},
+			expected:   evaluator.IntValue(6),
+		},
+		{
+			name:       "unary plus is a no-op",
+			expression: "+3",
+			vars:       map[string]evaluator.Value{},
+			expected:   evaluator.IntValue(3),
+		},
+		{
+			name:       "logical not of a bool",
+			expression: "!true",
+			vars:       map[string]evaluator.Value{},
+			expected:   evaluator.BoolValue(false),
+		},
+		{
+			name:       "bitwise complement of an int",
+			expression: "~0",
+			vars:       map[string]evaluator.Value{},
+			expected:   evaluator.IntValue(-1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateValue(tc.expression, tc.vars)
+
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// TestEvaluateValueUnaryTypeMismatch checks that applying a unary
+// operator to an incompatible Value kind returns ErrTypeMismatch.
+func TestEvaluateValueUnaryTypeMismatch(t *testing.T) {
+	_, err := evaluator.EvaluateValue(`-"a"`, map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrTypeMismatch))
+}
+
+// TestEvaluateValueTernary checks that cond ? then : else returns the
+// branch selected by a float comparison, using the rule from the request
+// that originally asked for ternary support.
+func TestEvaluateValueTernary(t *testing.T) {
+	vars := map[string]evaluator.Value{
+		"cluster_version": evaluator.FloatValue(4.11),
+		"cve_high_count":  evaluator.IntValue(3),
+	}
+
+	result, err := evaluator.EvaluateValue(
+		"cluster_version >= 4.10 ? cve_high_count : cve_high_count*2", vars)
+
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, evaluator.IntValue(3), result)
+}
+
+// TestEvaluateValueTernaryNonBoolCond checks that a non-bool cond
+// returns ErrTypeMismatch instead of silently picking a branch.
+func TestEvaluateValueTernaryNonBoolCond(t *testing.T) {
+	_, err := evaluator.EvaluateValue("1 ? 2 : 3", map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrTypeMismatch))
+}
+
+// TestEvaluateValueParseError checks that a malformed expression returns
+// a *evaluator.ParseError wrapping evaluator.ErrParse, the same as
+// Evaluate does.
+func TestEvaluateValueParseError(t *testing.T) {
+	_, err := evaluator.EvaluateValue("1**", map[string]evaluator.Value{})
+
+	assert.Error(t, err, "error is expected")
+	assert.True(t, errors.Is(err, evaluator.ErrParse))
+
+	var parseErr *evaluator.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+}
@@ -0,0 +1,164 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import "sort"
+
+// tokenize splits expression into a slice of tokens terminated by a single
+// tokenEOF token. symbols is the set of operator symbols currently
+// registered on the evaluator (e.g. "+", "&&", "<="); it drives how
+// punctuation is recognized so that operators registered via Option values
+// are lexed exactly like the built-in ones, with no hardcoded switch over
+// operator characters.
+func tokenize(expression string, symbols []string) ([]token, error) {
+	// Longest symbols must be tried first so that e.g. "<=" is not lexed
+	// as "<" followed by an unexpected "=".
+	sorted := append([]string(nil), symbols...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	var tokens []token
+	pos := 0
+	for pos < len(expression) {
+		c := expression[pos]
+
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			pos++
+			continue
+		}
+
+		switch {
+		case c == '0' && pos+1 < len(expression) && (expression[pos+1] == 'x' || expression[pos+1] == 'X'):
+			start := pos
+			pos += 2
+			for pos < len(expression) && isHexDigit(expression[pos]) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, lit: expression[start:pos], pos: start})
+			continue
+		case c >= '0' && c <= '9':
+			start := pos
+			for pos < len(expression) && expression[pos] >= '0' && expression[pos] <= '9' {
+				pos++
+			}
+			if pos+1 < len(expression) && expression[pos] == '.' && expression[pos+1] >= '0' && expression[pos+1] <= '9' {
+				pos++
+				for pos < len(expression) && expression[pos] >= '0' && expression[pos] <= '9' {
+					pos++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, lit: expression[start:pos], pos: start})
+			continue
+		case isIdentStart(c):
+			start := pos
+			for pos < len(expression) && isIdentPart(expression[pos]) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, lit: expression[start:pos], pos: start})
+			continue
+		case c == '"':
+			start := pos
+			lit, newPos, err := scanString(expression, pos)
+			if err != nil {
+				return nil, err
+			}
+			pos = newPos
+			tokens = append(tokens, token{kind: tokenString, lit: lit, pos: start})
+			continue
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, lit: "(", pos: pos})
+			pos++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, lit: ")", pos: pos})
+			pos++
+			continue
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, lit: ",", pos: pos})
+			pos++
+			continue
+		case c == '?':
+			tokens = append(tokens, token{kind: tokenQuestion, lit: "?", pos: pos})
+			pos++
+			continue
+		case c == ':':
+			tokens = append(tokens, token{kind: tokenColon, lit: ":", pos: pos})
+			pos++
+			continue
+		}
+
+		symbol, ok := matchSymbol(expression[pos:], sorted)
+		if !ok {
+			return nil, lexError(pos, string(c), "operator", "punctuation")
+		}
+		tokens = append(tokens, token{kind: tokenOperator, lit: symbol, pos: pos})
+		pos += len(symbol)
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: pos})
+	return tokens, nil
+}
+
+// matchSymbol returns the longest operator symbol that is a prefix of rest,
+// trying candidates in the order given (callers pass them longest-first).
+func matchSymbol(rest string, candidatesByLength []string) (string, bool) {
+	for _, symbol := range candidatesByLength {
+		if len(symbol) <= len(rest) && rest[:len(symbol)] == symbol {
+			return symbol, true
+		}
+	}
+	return "", false
+}
+
+// scanString reads a double-quoted string literal starting at pos (which
+// must hold the opening quote) and returns its unescaped content together
+// with the position right after the closing quote. It supports \" and \\
+// escapes.
+func scanString(expression string, pos int) (string, int, error) {
+	start := pos
+	pos++ // consume opening quote
+
+	var content []byte
+	for {
+		if pos >= len(expression) {
+			return "", 0, lexError(start, "", "closing '\"'")
+		}
+
+		c := expression[pos]
+		if c == '"' {
+			pos++
+			return string(content), pos, nil
+		}
+		if c == '\\' && pos+1 < len(expression) && (expression[pos+1] == '"' || expression[pos+1] == '\\') {
+			content = append(content, expression[pos+1])
+			pos += 2
+			continue
+		}
+
+		content = append(content, c)
+		pos++
+	}
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// Associativity describes how two binary operators of equal precedence
+// combine when they appear next to each other in an expression.
+type Associativity int
+
+const (
+	// LeftAssociative groups "a op b op c" as "(a op b) op c". This is
+	// the natural choice for arithmetic and relational operators.
+	LeftAssociative Associativity = iota
+	// RightAssociative groups "a op b op c" as "a op (b op c)".
+	RightAssociative
+)
+
+// BinaryHandler computes the result of applying a binary operator to its
+// left and right operands.
+type BinaryHandler func(left, right int) (int, error)
+
+// UnaryHandler computes the result of applying a unary (prefix) operator
+// to its single operand.
+type UnaryHandler func(operand int) (int, error)
+
+// FunctionHandler computes the result of calling a user-defined function
+// with the given arguments.
+type FunctionHandler func(args ...int) (int, error)
+
+// binaryOperator is the registered definition of a binary operator: its
+// symbol, where it sits in the precedence table, how it associates, and
+// the handler that computes its result.
+type binaryOperator struct {
+	symbol        string
+	precedence    int
+	associativity Associativity
+	handler       BinaryHandler
+}
+
+// unaryOperator is the registered definition of a prefix operator.
+type unaryOperator struct {
+	symbol  string
+	handler UnaryHandler
+}
+
+// function is the registered definition of a user-defined function
+// callable from expressions as name(arg1, arg2, ...).
+type function struct {
+	name    string
+	arity   int
+	handler FunctionHandler
+}
@@ -0,0 +1,256 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// parser builds an AST out of a token stream using precedence climbing:
+// the precedence and associativity of each binary operator is looked up
+// in the owning evaluator's operator table rather than hardcoded, so
+// operators registered via Option values parse exactly like built-in
+// ones.
+type parser struct {
+	ev     *Evaluator
+	tokens []token
+	pos    int
+}
+
+func newParser(tokens []token, ev *Evaluator) *parser {
+	return &parser{ev: ev, tokens: tokens}
+}
+
+func (p *parser) cur() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() {
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+}
+
+// parse consumes the whole token stream and returns the resulting AST, or
+// an error if the expression is empty or has trailing tokens that were
+// never consumed.
+func (p *parser) parse() (node, error) {
+	if p.cur().kind == tokenEOF {
+		return nil, parseError(p.cur(), "operand")
+	}
+
+	expr, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenEOF {
+		return nil, parseError(p.cur(), "end of expression")
+	}
+
+	return expr, nil
+}
+
+// parseTernary parses "cond ? then : else", which binds looser than ||
+// so it only ever wraps a whole binary expression. It is right-associative:
+// both the then- and else-branches may themselves be ternaries, so
+// "a ? b : c ? d : e" parses as "a ? b : (c ? d : e)".
+func (p *parser) parseTernary() (node, error) {
+	cond, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenQuestion {
+		return cond, nil
+	}
+	p.advance()
+
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenColon {
+		return nil, parseError(p.cur(), "':'")
+	}
+	p.advance()
+
+	els, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ternaryNode{cond: cond, then: then, els: els}, nil
+}
+
+// parseExpr implements precedence climbing: it parses a unary expression
+// and then repeatedly folds in binary operators whose precedence is at
+// least minPrec, recursing with a higher minimum for left-associative
+// operators so that equal-precedence operators group to the left.
+func (p *parser) parseExpr(minPrec int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.cur()
+		if tok.kind != tokenOperator {
+			break
+		}
+
+		def, ok := p.ev.binaryOps[tok.lit]
+		if !ok || def.precedence < minPrec {
+			break
+		}
+
+		p.advance()
+
+		nextMinPrec := def.precedence + 1
+		if def.associativity == RightAssociative {
+			nextMinPrec = def.precedence
+		}
+
+		right, err := p.parseExpr(nextMinPrec)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{symbol: def.symbol, handler: def.handler, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary recognizes a registered prefix operator in front of another
+// unary expression, falling back to parsePrimary otherwise. Symbols with
+// no registered unary operator (e.g. a leading "*") are left for
+// parsePrimary to reject.
+func (p *parser) parseUnary() (node, error) {
+	tok := p.cur()
+	if tok.kind == tokenOperator {
+		if def, ok := p.ev.unaryOps[tok.lit]; ok {
+			p.advance()
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &unaryNode{symbol: def.symbol, handler: def.handler, operand: operand}, nil
+		}
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a number, variable, function call, or a fully
+// parenthesized sub-expression.
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.cur()
+
+	switch tok.kind {
+	case tokenNumber:
+		value, err := parseIntLiteral(tok.lit)
+		if err != nil {
+			return nil, parseError(tok, "number literal")
+		}
+		p.advance()
+		return &numberNode{value: value}, nil
+
+	case tokenIdent:
+		p.advance()
+		if p.cur().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		return &identNode{name: tok.lit}, nil
+
+	case tokenLParen:
+		p.advance()
+		expr, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if p.cur().kind != tokenRParen {
+			return nil, parseError(p.cur(), "')'")
+		}
+		p.advance()
+		return expr, nil
+
+	default:
+		return nil, parseError(tok, "operand")
+	}
+}
+
+// parseCall parses the "(arg, arg, ...)" suffix of a function call whose
+// name token has already been consumed.
+func (p *parser) parseCall(nameTok token) (node, error) {
+	fn, ok := p.ev.functions[nameTok.lit]
+	if !ok {
+		return nil, parseError(nameTok, "a registered function name")
+	}
+
+	p.advance() // consume '('
+
+	var args []node
+	if p.cur().kind != tokenRParen {
+		for {
+			arg, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.cur().kind == tokenComma {
+				p.advance()
+				continue
+			}
+			break
+		}
+	}
+
+	if p.cur().kind != tokenRParen {
+		return nil, parseError(p.cur(), "','", "')'")
+	}
+	p.advance()
+
+	if len(args) != fn.arity {
+		return nil, parseError(nameTok, fmt.Sprintf("%d argument(s)", fn.arity))
+	}
+
+	return &callNode{name: fn.name, handler: fn.handler, args: args}, nil
+}
+
+// parseIntLiteral parses the literal text of a tokenNumber as an int. The
+// lexer only ever produces a "0x"/"0X" prefix for a hex literal, so that
+// prefix is the only case handed to base-16 parsing; every other literal
+// (including one with a leading zero, e.g. "008") is parsed as plain
+// base-10 so it means what it looks like, rather than being handed to
+// strconv's base-0 rules, which would treat a leading zero as an octal
+// prefix.
+func parseIntLiteral(lit string) (int, error) {
+	if len(lit) > 1 && lit[0] == '0' && (lit[1] == 'x' || lit[1] == 'X') {
+		parsed, err := strconv.ParseInt(lit[2:], 16, 64)
+		if err != nil {
+			return 0, err
+		}
+		return int(parsed), nil
+	}
+
+	parsed, err := strconv.ParseInt(lit, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int(parsed), nil
+}
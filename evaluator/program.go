@@ -0,0 +1,101 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// Program is an expression that has already been tokenized and parsed
+// into an AST, ready to be evaluated against many different sets of
+// values without paying the tokenizing/parsing cost again. Create one
+// with Compile or Evaluator.Compile.
+type Program struct {
+	ast  node
+	vars []string
+}
+
+// Vars returns the names of the free variables expression refers to, in
+// the order they first appear, so callers can validate their values map
+// once instead of on every Eval call.
+func (p *Program) Vars() []string {
+	return p.vars
+}
+
+// Eval evaluates the compiled expression against values. It returns 0
+// together with an error if values is missing one of Vars, or the
+// expression fails at runtime (e.g. division by zero); callers can tell
+// these apart with errors.Is against ErrUnknownIdent and ErrDivByZero.
+func (p *Program) Eval(values map[string]int) (int, error) {
+	result, err := p.ast.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// Compile parses expression using the operators and functions registered
+// on e and returns a reusable Program.
+func (e *Evaluator) Compile(expression string) (*Program, error) {
+	tokens, err := tokenize(expression, e.operatorSymbols())
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := newParser(tokens, e).parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Program{ast: ast, vars: collectVars(ast)}, nil
+}
+
+// Compile parses expression using the built-in operators - the same ones
+// Evaluate supports - and returns a reusable Program. Callers evaluating
+// the same expression against many values maps should Compile it once
+// and call Program.Eval repeatedly instead of calling Evaluate in a loop.
+func Compile(expression string) (*Program, error) {
+	return defaultEvaluator.Compile(expression)
+}
+
+// collectVars walks ast and returns the names of every identNode it
+// contains, in first-appearance order and without duplicates.
+func collectVars(n node) []string {
+	var order []string
+	seen := make(map[string]bool)
+
+	var walk func(node)
+	walk = func(n node) {
+		switch v := n.(type) {
+		case *identNode:
+			if !seen[v.name] {
+				seen[v.name] = true
+				order = append(order, v.name)
+			}
+		case *binaryNode:
+			walk(v.left)
+			walk(v.right)
+		case *unaryNode:
+			walk(v.operand)
+		case *ternaryNode:
+			walk(v.cond)
+			walk(v.then)
+			walk(v.els)
+		case *callNode:
+			for _, arg := range v.args {
+				walk(arg)
+			}
+		}
+	}
+	walk(n)
+
+	return order
+}
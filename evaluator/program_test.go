@@ -0,0 +1,56 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/RedHatInsights/insights-operator-utils/evaluator"
+)
+
+// TestCompileReuse checks that a Program compiled once can be evaluated
+// against several different values maps.
+func TestCompileReuse(t *testing.T) {
+	program, err := evaluator.Compile("x+y*2")
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := program.Eval(map[string]int{"x": 1, "y": 2})
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 5, result)
+
+	result, err = program.Eval(map[string]int{"x": 10, "y": 0})
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 10, result)
+}
+
+// TestProgramVars checks that a compiled Program reports the free
+// variables an expression refers to, in first-appearance order and
+// without duplicates.
+func TestProgramVars(t *testing.T) {
+	program, err := evaluator.Compile("x+y*x")
+	assert.NoError(t, err, "unexpected error")
+
+	assert.Equal(t, []string{"x", "y"}, program.Vars())
+}
+
+// TestCompileInvalidExpression checks that Compile reports a parse error
+// without returning a usable Program.
+func TestCompileInvalidExpression(t *testing.T) {
+	_, err := evaluator.Compile("1**")
+
+	assert.Error(t, err, "error is expected")
+}
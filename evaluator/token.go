@@ -0,0 +1,44 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// tokenKind identifies the lexical class of a token produced by the lexer.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenQuestion
+	tokenColon
+)
+
+// token is a single lexical unit produced by the lexer, together with its
+// textual representation and its byte offset in the original expression.
+// For tokenOperator, lit holds the operator symbol exactly as registered
+// (e.g. "+", "&&", "<="), so the parser can look it up in the evaluator's
+// operator tables without any hardcoded switch. For tokenNumber, lit may
+// contain a decimal point (e.g. "3.14"); for tokenString, lit holds the
+// literal's content with quoting and escaping already removed.
+type token struct {
+	kind tokenKind
+	lit  string
+	pos  int
+}
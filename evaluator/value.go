@@ -0,0 +1,94 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import "strconv"
+
+// ValueKind identifies which field of a Value is populated.
+type ValueKind int
+
+const (
+	// KindInt marks a Value holding an int in its Int field.
+	KindInt ValueKind = iota
+	// KindFloat marks a Value holding a float64 in its Float field.
+	KindFloat
+	// KindString marks a Value holding a string in its Str field.
+	KindString
+	// KindBool marks a Value holding a bool in its Bool field.
+	KindBool
+)
+
+// Value is the result type of EvaluateValue: a tagged union over the
+// types that expressions can produce, modeled after the constant.Value
+// kind tagging in the standard library's go/constant package.
+type Value struct {
+	Kind  ValueKind
+	Int   int
+	Float float64
+	Str   string
+	Bool  bool
+}
+
+// IntValue builds an int-valued Value.
+func IntValue(v int) Value {
+	return Value{Kind: KindInt, Int: v}
+}
+
+// FloatValue builds a float64-valued Value.
+func FloatValue(v float64) Value {
+	return Value{Kind: KindFloat, Float: v}
+}
+
+// StringValue builds a string-valued Value.
+func StringValue(v string) Value {
+	return Value{Kind: KindString, Str: v}
+}
+
+// BoolValue builds a bool-valued Value.
+func BoolValue(v bool) Value {
+	return Value{Kind: KindBool, Bool: v}
+}
+
+// String renders the Value for display: an int in base 10, a float64 in
+// strconv's shortest 'g' form (so 3.0 renders as "3"), a string quoted
+// and escaped by strconv.Quote, and a bool as true or false.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindInt:
+		return strconv.Itoa(v.Int)
+	case KindFloat:
+		return strconv.FormatFloat(v.Float, 'g', -1, 64)
+	case KindString:
+		return strconv.Quote(v.Str)
+	case KindBool:
+		return strconv.FormatBool(v.Bool)
+	default:
+		return "<invalid value>"
+	}
+}
+
+// isNumeric reports whether v holds an int or a float64.
+func (v Value) isNumeric() bool {
+	return v.Kind == KindInt || v.Kind == KindFloat
+}
+
+// asFloat returns v's numeric value as a float64. It must only be called
+// on a Value for which isNumeric reports true.
+func (v Value) asFloat() float64 {
+	if v.Kind == KindInt {
+		return float64(v.Int)
+	}
+	return v.Float
+}
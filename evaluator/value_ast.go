@@ -0,0 +1,117 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import "fmt"
+
+// valueNode is implemented by every element of the AST built by
+// valueParser for EvaluateValue.
+type valueNode interface {
+	eval(vars map[string]Value) (Value, error)
+}
+
+// literalValueNode is a literal int, float, string or bool.
+type literalValueNode struct {
+	value Value
+}
+
+func (n *literalValueNode) eval(map[string]Value) (Value, error) {
+	return n.value, nil
+}
+
+// valueIdentNode looks up a variable by name at evaluation time.
+type valueIdentNode struct {
+	name string
+}
+
+func (n *valueIdentNode) eval(vars map[string]Value) (Value, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return Value{}, fmt.Errorf("%w: %q", ErrUnknownIdent, n.name)
+	}
+	return value, nil
+}
+
+// valueBinaryNode applies one of the built-in value operators to its two
+// operands.
+type valueBinaryNode struct {
+	symbol string
+	left   valueNode
+	right  valueNode
+}
+
+func (n *valueBinaryNode) eval(vars map[string]Value) (Value, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return Value{}, err
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return Value{}, err
+	}
+
+	result, err := applyValueOperator(n.symbol, left, right)
+	if err != nil {
+		return Value{}, fmt.Errorf("operator %q: %w", n.symbol, err)
+	}
+	return result, nil
+}
+
+// valueUnaryNode applies one of the built-in prefix value operators to
+// its single operand.
+type valueUnaryNode struct {
+	symbol  string
+	operand valueNode
+}
+
+func (n *valueUnaryNode) eval(vars map[string]Value) (Value, error) {
+	operand, err := n.operand.eval(vars)
+	if err != nil {
+		return Value{}, err
+	}
+
+	result, err := applyValueUnaryOperator(n.symbol, operand)
+	if err != nil {
+		return Value{}, fmt.Errorf("operator %q: %w", n.symbol, err)
+	}
+	return result, nil
+}
+
+// valueTernaryNode implements "cond ? then : else" for EvaluateValue.
+// cond must evaluate to a bool; only the selected branch is evaluated,
+// so an error in the branch that is not taken (e.g. a division by zero)
+// is never reported.
+type valueTernaryNode struct {
+	cond valueNode
+	then valueNode
+	els  valueNode
+}
+
+func (n *valueTernaryNode) eval(vars map[string]Value) (Value, error) {
+	cond, err := n.cond.eval(vars)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if cond.Kind != KindBool {
+		return Value{}, ErrTypeMismatch
+	}
+
+	if cond.Bool {
+		return n.then.eval(vars)
+	}
+	return n.els.eval(vars)
+}
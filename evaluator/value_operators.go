@@ -0,0 +1,224 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+// valueOperatorSymbolsSupported lists every operator symbol
+// applyValueOperator knows how to apply. It drives
+// valueOperatorPrecedence below; keeping the two next to each other
+// keeps them from drifting apart as operators are added or removed.
+var valueOperatorSymbolsSupported = []string{
+	"||", "&&", "==", "!=", "<", ">", "<=", ">=", "+", "-", "*", "/", "%",
+}
+
+// valueUnaryOperatorSymbols lists every prefix operator symbol
+// applyValueUnaryOperator knows how to apply.
+var valueUnaryOperatorSymbols = []string{"-", "+", "!", "~"}
+
+// valueOperatorPrecedence gives the precedence of every operator
+// understood by EvaluateValue. It is read off defaultEvaluator's own
+// binaryOps table rather than hand-copied, so retuning a precedence
+// level in defaultOptions can't silently leave EvaluateValue out of
+// sync with Evaluate.
+var valueOperatorPrecedence = buildValueOperatorPrecedence()
+
+func buildValueOperatorPrecedence() map[string]int {
+	precedence := make(map[string]int, len(valueOperatorSymbolsSupported))
+	for _, symbol := range valueOperatorSymbolsSupported {
+		def, ok := defaultEvaluator.binaryOps[symbol]
+		if !ok {
+			panic("evaluator: no default precedence registered for value operator " + symbol)
+		}
+		precedence[symbol] = def.precedence
+	}
+	return precedence
+}
+
+// applyValueOperator evaluates the binary operator identified by symbol
+// against left and right, applying numeric promotion (int combined with
+// float64 promotes to float64) and reporting ErrTypeMismatch for operand
+// combinations the operator does not support.
+func applyValueOperator(symbol string, left, right Value) (Value, error) {
+	switch symbol {
+	case "+":
+		return applyAdd(left, right)
+	case "-", "*", "/", "%":
+		return applyArithmetic(symbol, left, right)
+	case "<", ">", "<=", ">=":
+		return applyRelational(symbol, left, right)
+	case "==", "!=":
+		return applyEquality(symbol, left, right)
+	case "&&", "||":
+		return applyLogical(symbol, left, right)
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+}
+
+// applyValueUnaryOperator evaluates the prefix operator identified by
+// symbol against operand, reporting ErrTypeMismatch if operand's Kind
+// does not support it.
+func applyValueUnaryOperator(symbol string, operand Value) (Value, error) {
+	switch symbol {
+	case "-":
+		if !operand.isNumeric() {
+			return Value{}, ErrTypeMismatch
+		}
+		if operand.Kind == KindInt {
+			return IntValue(-operand.Int), nil
+		}
+		return FloatValue(-operand.Float), nil
+	case "+":
+		if !operand.isNumeric() {
+			return Value{}, ErrTypeMismatch
+		}
+		return operand, nil
+	case "!":
+		if operand.Kind != KindBool {
+			return Value{}, ErrTypeMismatch
+		}
+		return BoolValue(!operand.Bool), nil
+	case "~":
+		if operand.Kind != KindInt {
+			return Value{}, ErrTypeMismatch
+		}
+		return IntValue(^operand.Int), nil
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+}
+
+func applyAdd(left, right Value) (Value, error) {
+	if left.Kind == KindString && right.Kind == KindString {
+		return StringValue(left.Str + right.Str), nil
+	}
+	return applyArithmetic("+", left, right)
+}
+
+func applyArithmetic(symbol string, left, right Value) (Value, error) {
+	if !left.isNumeric() || !right.isNumeric() {
+		return Value{}, ErrTypeMismatch
+	}
+
+	if left.Kind == KindInt && right.Kind == KindInt {
+		l, r := left.Int, right.Int
+		switch symbol {
+		case "+":
+			return IntValue(l + r), nil
+		case "-":
+			return IntValue(l - r), nil
+		case "*":
+			return IntValue(l * r), nil
+		case "/":
+			if r == 0 {
+				return Value{}, ErrDivisionByZero
+			}
+			return IntValue(l / r), nil
+		case "%":
+			if r == 0 {
+				return Value{}, ErrDivisionByZero
+			}
+			return IntValue(l % r), nil
+		}
+	}
+
+	l, r := left.asFloat(), right.asFloat()
+	switch symbol {
+	case "+":
+		return FloatValue(l + r), nil
+	case "-":
+		return FloatValue(l - r), nil
+	case "*":
+		return FloatValue(l * r), nil
+	case "/":
+		if r == 0 {
+			return Value{}, ErrDivisionByZero
+		}
+		return FloatValue(l / r), nil
+	case "%":
+		if r == 0 {
+			return Value{}, ErrDivisionByZero
+		}
+		return FloatValue(mod(l, r)), nil
+	}
+
+	return Value{}, ErrTypeMismatch
+}
+
+func mod(l, r float64) float64 {
+	quotient := int64(l / r)
+	return l - float64(quotient)*r
+}
+
+func applyRelational(symbol string, left, right Value) (Value, error) {
+	var less, equal bool
+
+	switch {
+	case left.isNumeric() && right.isNumeric():
+		l, r := left.asFloat(), right.asFloat()
+		less, equal = l < r, l == r
+	case left.Kind == KindString && right.Kind == KindString:
+		less, equal = left.Str < right.Str, left.Str == right.Str
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+
+	switch symbol {
+	case "<":
+		return BoolValue(less), nil
+	case ">":
+		return BoolValue(!less && !equal), nil
+	case "<=":
+		return BoolValue(less || equal), nil
+	case ">=":
+		return BoolValue(!less), nil
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+}
+
+func applyEquality(symbol string, left, right Value) (Value, error) {
+	var equal bool
+
+	switch {
+	case left.isNumeric() && right.isNumeric():
+		equal = left.asFloat() == right.asFloat()
+	case left.Kind == KindString && right.Kind == KindString:
+		equal = left.Str == right.Str
+	case left.Kind == KindBool && right.Kind == KindBool:
+		equal = left.Bool == right.Bool
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+
+	if symbol == "!=" {
+		equal = !equal
+	}
+	return BoolValue(equal), nil
+}
+
+func applyLogical(symbol string, left, right Value) (Value, error) {
+	if left.Kind != KindBool || right.Kind != KindBool {
+		return Value{}, ErrTypeMismatch
+	}
+
+	switch symbol {
+	case "&&":
+		return BoolValue(left.Bool && right.Bool), nil
+	case "||":
+		return BoolValue(left.Bool || right.Bool), nil
+	default:
+		return Value{}, ErrTypeMismatch
+	}
+}
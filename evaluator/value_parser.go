@@ -0,0 +1,194 @@
+// Copyright 2022 Red Hat, Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// valueParser builds a valueNode AST out of a token stream using the same
+// precedence-climbing approach as parser, but against the fixed set of
+// operators EvaluateValue supports.
+type valueParser struct {
+	tokens []token
+	pos    int
+}
+
+func newValueParser(tokens []token) *valueParser {
+	return &valueParser{tokens: tokens}
+}
+
+func (p *valueParser) cur() token {
+	return p.tokens[p.pos]
+}
+
+func (p *valueParser) advance() {
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+}
+
+func (p *valueParser) parse() (valueNode, error) {
+	if p.cur().kind == tokenEOF {
+		return nil, parseError(p.cur(), "operand")
+	}
+
+	expr, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenEOF {
+		return nil, parseError(p.cur(), "end of expression")
+	}
+
+	return expr, nil
+}
+
+// parseTernary parses "cond ? then : else", which binds looser than ||
+// so it only ever wraps a whole binary expression. It is
+// right-associative, mirroring parser.parseTernary.
+func (p *valueParser) parseTernary() (valueNode, error) {
+	cond, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenQuestion {
+		return cond, nil
+	}
+	p.advance()
+
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur().kind != tokenColon {
+		return nil, parseError(p.cur(), "':'")
+	}
+	p.advance()
+
+	els, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &valueTernaryNode{cond: cond, then: then, els: els}, nil
+}
+
+func (p *valueParser) parseExpr(minPrec int) (valueNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.cur()
+		if tok.kind != tokenOperator {
+			break
+		}
+
+		precedence, ok := valueOperatorPrecedence[tok.lit]
+		if !ok || precedence < minPrec {
+			break
+		}
+
+		p.advance()
+
+		// Every value operator is left-associative.
+		right, err := p.parseExpr(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &valueBinaryNode{symbol: tok.lit, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary recognizes a prefix operator in front of another unary
+// expression, falling back to parsePrimary otherwise, mirroring
+// parser.parseUnary.
+func (p *valueParser) parseUnary() (valueNode, error) {
+	tok := p.cur()
+	if tok.kind == tokenOperator {
+		for _, symbol := range valueUnaryOperatorSymbols {
+			if tok.lit != symbol {
+				continue
+			}
+			p.advance()
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &valueUnaryNode{symbol: symbol, operand: operand}, nil
+		}
+	}
+	return p.parsePrimary()
+}
+
+func (p *valueParser) parsePrimary() (valueNode, error) {
+	tok := p.cur()
+
+	switch tok.kind {
+	case tokenNumber:
+		p.advance()
+		if strings.Contains(tok.lit, ".") {
+			value, err := strconv.ParseFloat(tok.lit, 64)
+			if err != nil {
+				return nil, parseError(tok, "number literal")
+			}
+			return &literalValueNode{value: FloatValue(value)}, nil
+		}
+		value, err := strconv.Atoi(tok.lit)
+		if err != nil {
+			return nil, parseError(tok, "number literal")
+		}
+		return &literalValueNode{value: IntValue(value)}, nil
+
+	case tokenString:
+		p.advance()
+		return &literalValueNode{value: StringValue(tok.lit)}, nil
+
+	case tokenIdent:
+		p.advance()
+		switch tok.lit {
+		case "true":
+			return &literalValueNode{value: BoolValue(true)}, nil
+		case "false":
+			return &literalValueNode{value: BoolValue(false)}, nil
+		default:
+			return &valueIdentNode{name: tok.lit}, nil
+		}
+
+	case tokenLParen:
+		p.advance()
+		expr, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if p.cur().kind != tokenRParen {
+			return nil, parseError(p.cur(), "')'")
+		}
+		p.advance()
+		return expr, nil
+
+	default:
+		return nil, parseError(tok, "operand")
+	}
+}